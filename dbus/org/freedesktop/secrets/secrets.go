@@ -0,0 +1,189 @@
+// Code generated by the dbus stub generator from the
+// org.freedesktop.Secret.* introspection data. DO NOT EDIT.
+
+package secrets
+
+import (
+	"pkg.deepin.io/lib/dbus"
+)
+
+const (
+	ifcService    = "org.freedesktop.Secret.Service"
+	ifcCollection = "org.freedesktop.Secret.Collection"
+	ifcItem       = "org.freedesktop.Secret.Item"
+	ifcPrompt     = "org.freedesktop.Secret.Prompt"
+	ifcSession    = "org.freedesktop.Secret.Session"
+)
+
+// Secret对应org.freedesktop.Secret.Service中定义的Secret结构体。
+type Secret struct {
+	Session     dbus.ObjectPath
+	Parameters  []byte
+	Value       []byte
+	ContentType string
+}
+
+// Session是Service.OpenSession返回的加密会话，在Secret Service daemon中
+// 持有一份状态，使用完毕后必须Close，否则会随调用次数一直泄漏下去。
+type Session struct {
+	core dbus.BusObject
+	path dbus.ObjectPath
+}
+
+// Path返回该Session的D-Bus对象路径，用于填充Secret.Session字段。
+func (sess *Session) Path() dbus.ObjectPath {
+	return sess.path
+}
+
+// Close对应org.freedesktop.Secret.Session.Close。
+func (sess *Session) Close() error {
+	return sess.core.Call(ifcSession+".Close", 0).Err
+}
+
+// Service是org.freedesktop.Secret.Service的客户端代理。
+type Service struct {
+	core dbus.BusObject
+	conn *dbus.Conn
+	dest string
+}
+
+func NewService(dest string, path dbus.ObjectPath) (*Service, error) {
+	conn, err := dbus.SessionBus()
+	if err != nil {
+		return nil, err
+	}
+	return &Service{core: conn.Object(dest, path), conn: conn, dest: dest}, nil
+}
+
+// OpenSession对应org.freedesktop.Secret.Service.OpenSession，返回的Session
+// 在用完后必须调用Close，否则会在Secret Service daemon中一直占用资源。
+func (s *Service) OpenSession(algorithm string, input dbus.Variant) (*Session, error) {
+	var output dbus.Variant
+	var path dbus.ObjectPath
+	err := s.core.Call(ifcService+".OpenSession", 0, algorithm, input).Store(&output, &path)
+	if err != nil {
+		return nil, err
+	}
+	return &Session{core: s.conn.Object(s.dest, path), path: path}, nil
+}
+
+func (s *Service) SearchItems(attrs map[string]string) (unlocked, locked []dbus.ObjectPath, err error) {
+	err = s.core.Call(ifcService+".SearchItems", 0, attrs).Store(&unlocked, &locked)
+	return
+}
+
+func (s *Service) Unlock(objects []dbus.ObjectPath) (unlocked []dbus.ObjectPath, prompt dbus.ObjectPath, err error) {
+	err = s.core.Call(ifcService+".Unlock", 0, objects).Store(&unlocked, &prompt)
+	return
+}
+
+// Collection是org.freedesktop.Secret.Collection的客户端代理。
+type Collection struct {
+	core dbus.BusObject
+}
+
+func NewCollection(dest string, path dbus.ObjectPath) (*Collection, error) {
+	conn, err := dbus.SessionBus()
+	if err != nil {
+		return nil, err
+	}
+	return &Collection{core: conn.Object(dest, path)}, nil
+}
+
+func (c *Collection) CreateItem(properties map[string]dbus.Variant, secret Secret, replace bool) (item, prompt dbus.ObjectPath, err error) {
+	err = c.core.Call(ifcCollection+".CreateItem", 0, properties, secret, replace).Store(&item, &prompt)
+	return
+}
+
+// Item是org.freedesktop.Secret.Item的客户端代理。
+type Item struct {
+	core dbus.BusObject
+}
+
+func NewItem(dest string, path dbus.ObjectPath) (*Item, error) {
+	conn, err := dbus.SessionBus()
+	if err != nil {
+		return nil, err
+	}
+	return &Item{core: conn.Object(dest, path)}, nil
+}
+
+func (i *Item) GetSecret(session dbus.ObjectPath) (secret Secret, err error) {
+	err = i.core.Call(ifcItem+".GetSecret", 0, session).Store(&secret)
+	return
+}
+
+func (i *Item) Delete() (prompt dbus.ObjectPath, err error) {
+	err = i.core.Call(ifcItem+".Delete", 0).Store(&prompt)
+	return
+}
+
+// Prompt是org.freedesktop.Secret.Prompt的客户端代理，负责驱动需要用户
+// 确认的操作（如解锁、创建条目）走完Prompt/Dismiss流程。
+type Prompt struct {
+	core dbus.BusObject
+	conn *dbus.Conn
+	path dbus.ObjectPath
+	ch   chan *dbus.Signal
+	quit chan struct{}
+}
+
+func NewPrompt(dest string, path dbus.ObjectPath) (*Prompt, error) {
+	conn, err := dbus.SessionBus()
+	if err != nil {
+		return nil, err
+	}
+	return &Prompt{core: conn.Object(dest, path), conn: conn, path: path, quit: make(chan struct{})}, nil
+}
+
+func (p *Prompt) Prompt(windowId string) error {
+	return p.core.Call(ifcPrompt+".Prompt", 0, windowId).Err
+}
+
+// ConnectCompleted订阅Completed信号，在Prompt流程结束（无论是用户确认还
+// 是取消）后调用handler一次。订阅的channel由DestroyPrompt负责注销，调用方
+// 必须在Prompt流程结束或放弃等待时调用DestroyPrompt，否则会在共享连接上
+// 泄漏channel和一个阻塞的goroutine。
+func (p *Prompt) ConnectCompleted(handler func(dismissed bool, result dbus.Variant)) {
+	p.conn.BusObject().Call("org.freedesktop.DBus.AddMatch", 0, p.completedMatchRule())
+	p.ch = make(chan *dbus.Signal, 1)
+	p.conn.Signal(p.ch)
+
+	go func() {
+		for {
+			select {
+			case sig, ok := <-p.ch:
+				if !ok {
+					return
+				}
+				if sig.Path != p.path || sig.Name != ifcPrompt+".Completed" {
+					continue
+				}
+				if len(sig.Body) < 2 {
+					continue
+				}
+				dismissed, _ := sig.Body[0].(bool)
+				result, _ := sig.Body[1].(dbus.Variant)
+				handler(dismissed, result)
+				return
+			case <-p.quit:
+				return
+			}
+		}
+	}()
+}
+
+// DestroyPrompt注销ConnectCompleted注册的信号订阅并让其监听goroutine退出，
+// 调用方应在Prompt流程结束（无论成功、取消还是等待超时）后调用一次。
+func DestroyPrompt(p *Prompt) {
+	if p.ch != nil {
+		p.conn.RemoveSignal(p.ch)
+		p.conn.BusObject().Call("org.freedesktop.DBus.RemoveMatch", 0, p.completedMatchRule())
+	}
+	close(p.quit)
+}
+
+// completedMatchRule返回让总线把该Prompt对象的Completed信号路由到本连接的match规则。
+func (p *Prompt) completedMatchRule() string {
+	return "type='signal',interface='" + ifcPrompt + "',member='Completed',path='" + string(p.path) + "'"
+}
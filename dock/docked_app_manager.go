@@ -41,6 +41,8 @@ type DockedAppManager struct {
 	Docked func(id string) // find indicator on front-end.
 	// Undocked是信号，在某已驻留程序被移除驻留后被触发，将被移除程序id发送给信号接受者。
 	Undocked func(id string)
+	// Moved是信号，在已驻留程序的顺序被调整后被触发，携带程序id、原索引及新索引。
+	Moved func(appId string, oldIndex, newIndex int32)
 }
 
 func NewDockedAppManager(dockManager *DockManager) *DockedAppManager {
@@ -90,7 +92,8 @@ func (m *DockedAppManager) handleOldConfigFile() {
 		logger.Debug("Read docked app from old config file failed:", err)
 		return
 	}
-	ids = uniqStrSlice(ids)
+	// 使用保序的去重方式，避免Position原有的驻留顺序被map的遍历顺序打乱。
+	ids = dedupPreserveOrder(ids)
 	for _, id := range ids {
 		if a := NewAppInfo(id); a != nil {
 			a.Destroy()
@@ -151,6 +154,7 @@ func (m *DockedAppManager) dockAppEntry(entry *AppEntry) bool {
 
 func (m *DockedAppManager) undockAppEntry(appId string) bool {
 	m.saveDockedAppList()
+	deleteAppSecrets(appId)
 	m.emitSignal("Undocked", appId)
 	return true
 }
@@ -180,10 +184,15 @@ func (m *DockedAppManager) saveAppList(apps []string) {
 
 func (m *DockedAppManager) saveDockedAppList() {
 	apps := m.dockManager.getDockedAppList()
-	if !strSliceEqual(m.dockedAppList, apps) {
-		logger.Debugf("Save gsettings %s: %#v", settingKeyDockedApps, apps)
-		m.saveAppList(apps)
-		m.dockedAppList = apps
+	// dockManager只知道哪些程序当前处于驻留状态，并不知道RequestReorder/
+	// MoveBefore设置的显示顺序，所以这里基于现有的dockedAppList合并出新列
+	// 表，而不是直接用dockManager的顺序覆盖，否则每次dock/undock都会把
+	// 拖拽调整过的顺序冲掉。
+	merged := mergePreservingOrder(m.dockedAppList, apps)
+	if !strSliceEqual(m.dockedAppList, merged) {
+		logger.Debugf("Save gsettings %s: %#v", settingKeyDockedApps, merged)
+		m.saveAppList(merged)
+		m.dockedAppList = merged
 	}
 }
 
@@ -205,3 +214,122 @@ func (m *DockedAppManager) ReqeustDock(id, title, icon, cmd string) bool {
 // Sort 废弃
 func (m *DockedAppManager) Sort([]string) {
 }
+
+// GetOrderedAppList返回当前已驻留程序的id列表，顺序与任务栏中的显示顺序一致。
+func (m *DockedAppManager) GetOrderedAppList() []string {
+	list := make([]string, len(m.dockedAppList))
+	copy(list, m.dockedAppList)
+	return list
+}
+
+// RequestReorder将appId移动到newIndex所在位置，其余程序顺延。移动成功后
+// 会触发Moved信号，并将新顺序持久化到docked-apps GSettings键。
+func (m *DockedAppManager) RequestReorder(appId string, newIndex int32) bool {
+	list, oldIndex, ok := reorderList(m.dockedAppList, appId, int(newIndex))
+	if !ok {
+		logger.Warning("RequestReorder failed for app:", appId, "newIndex:", newIndex)
+		return false
+	}
+	if oldIndex == int(newIndex) {
+		return true
+	}
+
+	m.dockedAppList = list
+	m.saveAppList(list)
+	m.emitSignal("Moved", appId, int32(oldIndex), newIndex)
+	return true
+}
+
+// MoveBefore将appId移动到targetId前面，是RequestReorder的便捷封装，
+// 便于前端实现拖拽排序而无需自己计算目标索引。
+func (m *DockedAppManager) MoveBefore(appId, targetId string) bool {
+	if appId == targetId {
+		return false
+	}
+
+	targetIndex := indexOfStr(targetId, m.dockedAppList)
+	if targetIndex == -1 {
+		logger.Warning("MoveBefore failed, target app not docked:", targetId)
+		return false
+	}
+	oldIndex := indexOfStr(appId, m.dockedAppList)
+	if oldIndex == -1 {
+		logger.Warning("MoveBefore failed, app not docked:", appId)
+		return false
+	}
+
+	newIndex := targetIndex
+	if oldIndex < targetIndex {
+		newIndex--
+	}
+	return m.RequestReorder(appId, int32(newIndex))
+}
+
+// reorderList返回将list中的appId移动到newIndex位置后的新切片，以及appId
+// 移动前的下标。appId不在list中或newIndex越界时，ok为false。list本身不
+// 会被修改。
+func reorderList(list []string, appId string, newIndex int) (result []string, oldIndex int, ok bool) {
+	oldIndex = indexOfStr(appId, list)
+	if oldIndex == -1 || newIndex < 0 || newIndex >= len(list) {
+		return nil, oldIndex, false
+	}
+	if newIndex == oldIndex {
+		return list, oldIndex, true
+	}
+
+	result = make([]string, len(list))
+	copy(result, list)
+	result = append(result[:oldIndex], result[oldIndex+1:]...)
+	result = append(result[:newIndex], append([]string{appId}, result[newIndex:]...)...)
+	return result, oldIndex, true
+}
+
+// mergePreservingOrder以oldOrder中的相对顺序为准，保留current中仍然存在的
+// 程序，并将current中新出现（即新驻留）的程序追加到末尾，从而让
+// RequestReorder/MoveBefore设置的顺序在之后的dock/undock事件中得以保留。
+func mergePreservingOrder(oldOrder, current []string) []string {
+	currentSet := make(map[string]bool, len(current))
+	for _, id := range current {
+		currentSet[id] = true
+	}
+
+	seen := make(map[string]bool, len(current))
+	merged := make([]string, 0, len(current))
+	for _, id := range oldOrder {
+		if currentSet[id] && !seen[id] {
+			merged = append(merged, id)
+			seen[id] = true
+		}
+	}
+	for _, id := range current {
+		if !seen[id] {
+			merged = append(merged, id)
+			seen[id] = true
+		}
+	}
+	return merged
+}
+
+// indexOfStr返回s在slice中的下标，不存在时返回-1。
+func indexOfStr(s string, slice []string) int {
+	for i, v := range slice {
+		if v == s {
+			return i
+		}
+	}
+	return -1
+}
+
+// dedupPreserveOrder对ids去重，但保留元素首次出现时的相对顺序。
+func dedupPreserveOrder(ids []string) []string {
+	seen := make(map[string]bool, len(ids))
+	result := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		result = append(result, id)
+	}
+	return result
+}
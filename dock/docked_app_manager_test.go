@@ -0,0 +1,84 @@
+/**
+ * Copyright (C) 2014 Deepin Technology Co., Ltd.
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 3 of the License, or
+ * (at your option) any later version.
+ **/
+
+package dock
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestReorderList(t *testing.T) {
+	list := []string{"a", "b", "c", "d"}
+
+	result, oldIndex, ok := reorderList(list, "d", 0)
+	if !ok || oldIndex != 3 {
+		t.Fatalf("reorderList() ok=%v oldIndex=%v, want ok=true oldIndex=3", ok, oldIndex)
+	}
+	want := []string{"d", "a", "b", "c"}
+	if !reflect.DeepEqual(result, want) {
+		t.Errorf("reorderList() = %v, want %v", result, want)
+	}
+	// 原切片不应被修改。
+	if !reflect.DeepEqual(list, []string{"a", "b", "c", "d"}) {
+		t.Errorf("reorderList() mutated input list: %v", list)
+	}
+
+	if _, _, ok := reorderList(list, "unknown", 0); ok {
+		t.Error("reorderList() with unknown appId should fail")
+	}
+	if _, _, ok := reorderList(list, "a", 10); ok {
+		t.Error("reorderList() with out-of-range index should fail")
+	}
+}
+
+func TestMergePreservingOrderUndockPreservesOrder(t *testing.T) {
+	oldOrder := []string{"c", "a", "b"}
+	current := []string{"a", "b"} // c被移除驻留
+
+	got := mergePreservingOrder(oldOrder, current)
+	want := []string{"a", "b"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("mergePreservingOrder() = %v, want %v", got, want)
+	}
+}
+
+func TestMergePreservingOrderDockAtIndex(t *testing.T) {
+	// 先将c拖拽到最前面。
+	oldOrder, _, ok := reorderList([]string{"a", "b", "c"}, "c", 0)
+	if !ok {
+		t.Fatal("reorderList() failed")
+	}
+
+	// 新驻留了一个程序d，dockManager只知道新的驻留集合，不知道顺序。
+	current := []string{"a", "b", "c", "d"}
+	got := mergePreservingOrder(oldOrder, current)
+	want := []string{"c", "a", "b", "d"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("mergePreservingOrder() = %v, want %v", got, want)
+	}
+}
+
+func TestDedupPreserveOrder(t *testing.T) {
+	got := dedupPreserveOrder([]string{"a", "b", "a", "c", "b"})
+	want := []string{"a", "b", "c"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("dedupPreserveOrder() = %v, want %v", got, want)
+	}
+}
+
+func TestIndexOfStr(t *testing.T) {
+	list := []string{"a", "b", "c"}
+	if got := indexOfStr("b", list); got != 1 {
+		t.Errorf("indexOfStr() = %d, want 1", got)
+	}
+	if got := indexOfStr("z", list); got != -1 {
+		t.Errorf("indexOfStr() = %d, want -1", got)
+	}
+}
@@ -0,0 +1,204 @@
+/**
+ * Copyright (C) 2014 Deepin Technology Co., Ltd.
+ *
+ * This program is free software; you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation; either version 3 of the License, or
+ * (at your option) any later version.
+ **/
+
+package dock
+
+import (
+	"dbus/org/freedesktop/secrets"
+	"pkg.deepin.io/lib/dbus"
+	"time"
+)
+
+const (
+	secretServiceDest       = "org.freedesktop.secrets"
+	secretServicePath       = "/org/freedesktop/secrets"
+	secretDefaultCollection = "/org/freedesktop/secrets/aliases/default"
+
+	secretAttrProfile = "profile"
+	secretAttrKey     = "key"
+)
+
+// SetAppSecret通过freedesktop Secret Service D-Bus接口（Collection.CreateItem）
+// 为appId保存一份与key关联的密钥（例如自建远程服务的访问令牌），使其不必被
+// 写进dockedItemTemplate生成的.desktop文件的Exec里。
+func (m *DockedAppManager) SetAppSecret(appId, key, secret string) bool {
+	service, err := secrets.NewService(secretServiceDest, secretServicePath)
+	if err != nil {
+		logger.Warning("SetAppSecret: connect to Secret Service failed:", err)
+		return false
+	}
+
+	collection, err := secrets.NewCollection(secretServiceDest, secretDefaultCollection)
+	if err != nil {
+		logger.Warning("SetAppSecret: open default collection failed:", err)
+		return false
+	}
+
+	session, err := service.OpenSession("plain", dbus.MakeVariant(""))
+	if err != nil {
+		logger.Warning("SetAppSecret: open session failed:", err)
+		return false
+	}
+	defer session.Close()
+
+	properties := map[string]dbus.Variant{
+		"org.freedesktop.Secret.Item.Label": dbus.MakeVariant(appId + "/" + key),
+		"org.freedesktop.Secret.Item.Attributes": dbus.MakeVariant(map[string]string{
+			secretAttrProfile: appId,
+			secretAttrKey:     key,
+		}),
+	}
+	value := secrets.Secret{
+		Session:     session.Path(),
+		Value:       []byte(secret),
+		ContentType: "text/plain",
+	}
+
+	_, promptPath, err := collection.CreateItem(properties, value, true)
+	if err != nil {
+		logger.Warning("SetAppSecret: create item failed:", err)
+		return false
+	}
+
+	if promptPath != dbus.ObjectPath("/") && !runSecretPrompt(promptPath) {
+		logger.Warning("SetAppSecret: prompt dismissed for", appId, key)
+		return false
+	}
+
+	return true
+}
+
+// GetAppSecret通过attributes{profile: appId, key: key}在默认collection中检索
+// 密钥。若条目被锁定或Secret Service要求用户确认，会驱动Prompt接口走完整个
+// 流程后再返回。
+func (m *DockedAppManager) GetAppSecret(appId, key string) (string, bool) {
+	service, err := secrets.NewService(secretServiceDest, secretServicePath)
+	if err != nil {
+		logger.Warning("GetAppSecret: connect to Secret Service failed:", err)
+		return "", false
+	}
+
+	attrs := map[string]string{
+		secretAttrProfile: appId,
+		secretAttrKey:     key,
+	}
+	items, locked, err := service.SearchItems(attrs)
+	if err != nil {
+		logger.Warning("GetAppSecret: search items failed:", err)
+		return "", false
+	}
+
+	if len(items) == 0 && len(locked) > 0 {
+		unlocked, promptPath, err := service.Unlock(locked)
+		if err != nil {
+			logger.Warning("GetAppSecret: unlock failed:", err)
+			return "", false
+		}
+		if promptPath != dbus.ObjectPath("/") {
+			if !runSecretPrompt(promptPath) {
+				return "", false
+			}
+			// 经Prompt解锁的条目不在Unlock的直接返回值中。
+			unlocked = locked
+		}
+		items = unlocked
+	}
+
+	if len(items) == 0 {
+		return "", false
+	}
+
+	session, err := service.OpenSession("plain", dbus.MakeVariant(""))
+	if err != nil {
+		logger.Warning("GetAppSecret: open session failed:", err)
+		return "", false
+	}
+	defer session.Close()
+
+	item, err := secrets.NewItem(secretServiceDest, items[0])
+	if err != nil {
+		logger.Warning("GetAppSecret: get item failed:", err)
+		return "", false
+	}
+
+	value, err := item.GetSecret(session.Path())
+	if err != nil {
+		logger.Warning("GetAppSecret: get secret failed:", err)
+		return "", false
+	}
+
+	return string(value.Value), true
+}
+
+// deleteAppSecrets在appId被移除驻留时清理它在Secret Service中保存的密钥项，
+// 由undockAppEntry调用。只删除SearchItems返回的未锁定条目，处于锁定状态的
+// 条目不会被删除。
+func deleteAppSecrets(appId string) {
+	service, err := secrets.NewService(secretServiceDest, secretServicePath)
+	if err != nil {
+		logger.Warning("deleteAppSecrets: connect to Secret Service failed:", err)
+		return
+	}
+
+	items, _, err := service.SearchItems(map[string]string{secretAttrProfile: appId})
+	if err != nil {
+		logger.Warning("deleteAppSecrets: search items failed:", err)
+		return
+	}
+
+	for _, path := range items {
+		item, err := secrets.NewItem(secretServiceDest, path)
+		if err != nil {
+			continue
+		}
+
+		promptPath, err := item.Delete()
+		if err != nil {
+			logger.Warning("deleteAppSecrets: delete item failed:", err)
+			continue
+		}
+		if promptPath != dbus.ObjectPath("/") {
+			runSecretPrompt(promptPath)
+		}
+	}
+}
+
+// secretPromptTimeout限制等待用户确认Prompt的时长，避免daemon重启或
+// Completed信号从未到达时把调用方的D-Bus方法永远挂起。
+const secretPromptTimeout = 2 * time.Minute
+
+// runSecretPrompt驱动Secret Service返回的Prompt对象走完整个提示流程，阻塞
+// 直到收到Completed信号或等待超时，返回用户是否完成了确认（而非取消或超
+// 时）。无论哪种结果，都会通过DestroyPrompt注销信号订阅，避免泄漏。
+func runSecretPrompt(promptPath dbus.ObjectPath) bool {
+	prompt, err := secrets.NewPrompt(secretServiceDest, promptPath)
+	if err != nil {
+		logger.Warning("runSecretPrompt: get prompt failed:", err)
+		return false
+	}
+	defer secrets.DestroyPrompt(prompt)
+
+	done := make(chan bool, 1)
+	prompt.ConnectCompleted(func(dismissed bool, result dbus.Variant) {
+		done <- !dismissed
+	})
+
+	if err := prompt.Prompt(""); err != nil {
+		logger.Warning("runSecretPrompt: prompt failed:", err)
+		return false
+	}
+
+	select {
+	case completed := <-done:
+		return completed
+	case <-time.After(secretPromptTimeout):
+		logger.Warning("runSecretPrompt: timed out waiting for Completed:", promptPath)
+		return false
+	}
+}
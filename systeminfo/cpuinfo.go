@@ -0,0 +1,100 @@
+package systeminfo
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+)
+
+const _PROC_CPU_INFO = "/proc/cpuinfo"
+
+// CpuInfo保存/proc/cpuinfo中与处理器拓扑相关的字段。Sockets、Cores、Threads
+// 分别表示物理CPU个数、物理核心总数以及逻辑线程总数。
+type CpuInfo struct {
+	ModelName string
+	Sockets   int
+	Cores     int
+	Threads   int
+	MHz       float64
+}
+
+// ReadCpuInfo解析/proc/cpuinfo，按physical id、core id对处理器拓扑去重，
+// 取代GetCpuInfo中直接数"model name"出现次数的做法。
+func ReadCpuInfo() (*CpuInfo, error) {
+	if IsFileNotExist(_PROC_CPU_INFO) {
+		return &CpuInfo{ModelName: "Unknown"}, nil
+	}
+
+	contents, err := ioutil.ReadFile(_PROC_CPU_INFO)
+	if err != nil {
+		logger.Infof("Read File Failed In Read Cpu Info: %s", err)
+		return nil, err
+	}
+
+	info := &CpuInfo{}
+	sockets := make(map[string]bool)
+	cores := make(map[string]bool)
+	hasTopology := false
+
+	physicalID := "0"
+	coreID := "0"
+	blocks := strings.Split(string(contents), "\n\n")
+	for _, block := range blocks {
+		lines := strings.Split(block, "\n")
+		for _, line := range lines {
+			vars := strings.SplitN(line, ":", 2)
+			if len(vars) < 2 {
+				continue
+			}
+			key := strings.TrimSpace(vars[0])
+			value := strings.TrimSpace(vars[1])
+
+			switch key {
+			case "processor":
+				info.Threads++
+			case "physical id":
+				physicalID = value
+				sockets[physicalID] = true
+				hasTopology = true
+			case "core id":
+				coreID = value
+				hasTopology = true
+			case "model name":
+				if info.ModelName == "" {
+					info.ModelName = value
+				}
+			case "cpu MHz":
+				if info.MHz == 0 {
+					info.MHz, _ = strconv.ParseFloat(value, 64)
+				}
+			}
+		}
+		cores[physicalID+"/"+coreID] = true
+	}
+
+	if hasTopology {
+		// x86风格的/proc/cpuinfo，按physical id/core id去重得到真实拓扑。
+		info.Sockets = len(sockets)
+		info.Cores = len(cores)
+	} else {
+		// ARM等不输出physical id/core id的架构，没有拓扑信息可去重，
+		// 此时不能假装只有一个核心，退化为把每个逻辑线程当作一个核心。
+		info.Sockets = 1
+		info.Cores = info.Threads
+	}
+
+	if info.ModelName == "" {
+		info.ModelName = "Unknown"
+	}
+
+	return info, nil
+}
+
+// String格式化为"N cores @ X GHz"的形式，供前端展示。
+func (info *CpuInfo) String() string {
+	if info.Cores == 0 {
+		return info.ModelName
+	}
+	return fmt.Sprintf("%d cores @ %.2f GHz", info.Cores, info.MHz/1000)
+}
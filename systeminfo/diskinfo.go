@@ -0,0 +1,158 @@
+package systeminfo
+
+import (
+	"dbus/org/freedesktop/udisks2"
+	"pkg.linuxdeepin.com/lib/dbus"
+	"strings"
+	"syscall"
+)
+
+// DiskInfo描述一块UDisks2枚举到的磁盘及其挂载使用情况，相比GetDiskCap只
+// 汇总非可移动驱动器的总容量，这里按分区保留了完整的细节。
+type DiskInfo struct {
+	Device        string
+	Model         string
+	Serial        string
+	Size          uint64
+	Removable     bool
+	Rotational    bool
+	ConnectionBus string
+	Mountpoints   []string
+	FSType        string
+	UsedBytes     uint64
+	AvailBytes    uint64
+}
+
+// GetDiskInfo遍历UDisks2的Block与Filesystem接口，对每个分区读取其所属
+// Drive的属性，再用syscall.Statfs统计已挂载分区的使用量。是SystemInfo的
+// D-Bus方法，供DisksChanged信号的接收者在磁盘增减后重新查询最新列表。
+func (sys *SystemInfo) GetDiskInfo() []DiskInfo {
+	obj, err := udisks2.NewObjectManager("org.freedesktop.UDisks2", "/org/freedesktop/UDisks2")
+	if err != nil {
+		logger.Infof("udisks2: New ObjectManager Failed:%v", err)
+		return nil
+	}
+	managers, _ := obj.GetManagedObjects()
+
+	var disks []DiskInfo
+	for path, ifaces := range managers {
+		block, ok := ifaces["org.freedesktop.UDisks2.Block"]
+		if !ok {
+			continue
+		}
+
+		info := DiskInfo{Device: getDevice(block, path)}
+
+		if v, ok := block["IdType"]; ok {
+			info.FSType, _ = v.Value().(string)
+		}
+
+		if v, ok := block["Drive"]; ok {
+			if drivePath, ok := v.Value().(dbus.ObjectPath); ok && drivePath != dbus.ObjectPath("/") {
+				if drive, ok := managers[drivePath]["org.freedesktop.UDisks2.Drive"]; ok {
+					fillDriveInfo(&info, drive)
+				}
+			}
+		}
+
+		if fs, ok := ifaces["org.freedesktop.UDisks2.Filesystem"]; ok {
+			info.Mountpoints = getMountPoints(fs)
+		}
+
+		for _, mountpoint := range info.Mountpoints {
+			used, avail, err := statfsUsage(mountpoint)
+			if err != nil {
+				continue
+			}
+			info.UsedBytes = used
+			info.AvailBytes = avail
+			break
+		}
+
+		disks = append(disks, info)
+	}
+
+	return disks
+}
+
+func fillDriveInfo(info *DiskInfo, drive map[string]dbus.Variant) {
+	if v, ok := drive["Model"]; ok {
+		info.Model, _ = v.Value().(string)
+	}
+	if v, ok := drive["Serial"]; ok {
+		info.Serial, _ = v.Value().(string)
+	}
+	if v, ok := drive["Size"]; ok {
+		info.Size, _ = v.Value().(uint64)
+	}
+	if v, ok := drive["Removable"]; ok {
+		info.Removable, _ = v.Value().(bool)
+	}
+	if v, ok := drive["Rotational"]; ok {
+		info.Rotational, _ = v.Value().(bool)
+	}
+	if v, ok := drive["ConnectionBus"]; ok {
+		info.ConnectionBus, _ = v.Value().(string)
+	}
+}
+
+// getDevice读取Block接口的"Device"属性（形如"/dev/sda1"的以NUL结尾的字节
+// 数组），而不是用UDisks2的D-Bus对象路径冒充设备节点。属性缺失时才回退到
+// 对象路径，以保证调用方至少能拿到一个可区分不同分区的标识。
+func getDevice(block map[string]dbus.Variant, path dbus.ObjectPath) string {
+	if v, ok := block["Device"]; ok {
+		if raw, ok := v.Value().([]byte); ok {
+			if device := strings.TrimRight(string(raw), "\x00"); device != "" {
+				return device
+			}
+		}
+	}
+
+	return string(path)
+}
+
+func getMountPoints(fs map[string]dbus.Variant) []string {
+	v, ok := fs["MountPoints"]
+	if !ok {
+		return nil
+	}
+	raw, ok := v.Value().([][]byte)
+	if !ok {
+		return nil
+	}
+
+	mountpoints := make([]string, 0, len(raw))
+	for _, b := range raw {
+		mountpoints = append(mountpoints, strings.TrimRight(string(b), "\x00"))
+	}
+	return mountpoints
+}
+
+func statfsUsage(mountpoint string) (used, avail uint64, err error) {
+	var stat syscall.Statfs_t
+	if err = syscall.Statfs(mountpoint, &stat); err != nil {
+		return 0, 0, err
+	}
+
+	blockSize := uint64(stat.Bsize)
+	used = (stat.Blocks - stat.Bfree) * blockSize
+	avail = stat.Bavail * blockSize
+	return
+}
+
+// watchDisks订阅UDisks2的InterfacesAdded/InterfacesRemoved信号，在磁盘或
+// 分区增减时触发DisksChanged，使控制中心不必轮询即可刷新分区使用率列表。
+func (sys *SystemInfo) watchDisks() {
+	obj, err := udisks2.NewObjectManager("org.freedesktop.UDisks2", "/org/freedesktop/UDisks2")
+	if err != nil {
+		logger.Infof("udisks2: New ObjectManager Failed:%v", err)
+		return
+	}
+
+	obj.ConnectInterfacesAdded(func(path dbus.ObjectPath, ifaces map[string]map[string]dbus.Variant) {
+		dbus.Emit(sys, "DisksChanged")
+	})
+	obj.ConnectInterfacesRemoved(func(path dbus.ObjectPath, ifaces []string) {
+		dbus.Emit(sys, "DisksChanged")
+	})
+}
@@ -0,0 +1,71 @@
+package systeminfo
+
+import (
+	"io/ioutil"
+	"strconv"
+	"strings"
+)
+
+const _PROC_MEM_INFO = "/proc/meminfo"
+
+// MemInfo保存/proc/meminfo中与内存压力相关的字段，单位均为字节，命名与读法
+// 参照了Docker sysinfo.MemInfo的做法。
+type MemInfo struct {
+	MemTotal     uint64
+	MemFree      uint64
+	MemAvailable uint64
+	Buffers      uint64
+	Cached       uint64
+	SwapTotal    uint64
+	SwapFree     uint64
+}
+
+// ReadMemInfo解析/proc/meminfo，返回完整的内存统计信息，而不是像
+// GetMemoryCap那样只挑出MemTotal一个字段。
+func ReadMemInfo() (*MemInfo, error) {
+	if IsFileNotExist(_PROC_MEM_INFO) {
+		return &MemInfo{}, nil
+	}
+
+	contents, err := ioutil.ReadFile(_PROC_MEM_INFO)
+	if err != nil {
+		logger.Infof("Read File Failed In Read Mem Info: %s", err)
+		return nil, err
+	}
+
+	info := &MemInfo{}
+	lines := strings.Split(string(contents), "\n")
+	for _, line := range lines {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+
+		key := strings.TrimSuffix(fields[0], ":")
+		value, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		// /proc/meminfo以kB为单位。
+		value *= 1024
+
+		switch key {
+		case "MemTotal":
+			info.MemTotal = value
+		case "MemFree":
+			info.MemFree = value
+		case "MemAvailable":
+			info.MemAvailable = value
+		case "Buffers":
+			info.Buffers = value
+		case "Cached":
+			info.Cached = value
+		case "SwapTotal":
+			info.SwapTotal = value
+		case "SwapFree":
+			info.SwapFree = value
+		}
+	}
+
+	return info, nil
+}
@@ -0,0 +1,153 @@
+package systeminfo
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+const (
+	_OS_RELEASE_ETC     = "/etc/os-release"
+	_DEBIAN_VERSION_ETC = "/etc/debian_version"
+	_PROC_VERSION       = "/proc/version"
+	_PROC_1_CGROUP      = "/proc/1/cgroup"
+	_DOCKERENV          = "/.dockerenv"
+)
+
+// OSInfo保存发行版信息，PrettyName用于展示，ID/VersionID/VersionCodename
+// 用于程序判断发行版及版本。
+type OSInfo struct {
+	PrettyName      string
+	ID              string
+	VersionID       string
+	VersionCodename string
+}
+
+// GetOperatingSystem依次尝试/etc/os-release、/etc/lsb-release、
+// /etc/debian_version，最后回退到`uname -sr`，解决GetVersion只认
+// DISTRIB_RELEASE、在很多Debian系发行版上读不到版本号的问题。
+func GetOperatingSystem() *OSInfo {
+	if info := readOSRelease(); info != nil {
+		return info
+	}
+
+	if version := GetVersion(); version != "" {
+		return &OSInfo{PrettyName: version, VersionID: version}
+	}
+
+	if !IsFileNotExist(_DEBIAN_VERSION_ETC) {
+		contents, err := ioutil.ReadFile(_DEBIAN_VERSION_ETC)
+		if err == nil {
+			version := strings.TrimSpace(string(contents))
+			return &OSInfo{PrettyName: "Debian " + version, ID: "debian", VersionID: version}
+		}
+	}
+
+	cmd := exec.Command("/bin/uname", "-sr")
+	out, err := cmd.Output()
+	if err != nil {
+		logger.Infof("Exec 'uname -sr' Failed In Get Operating System: %s", err)
+		return &OSInfo{PrettyName: "Unknown"}
+	}
+
+	return &OSInfo{PrettyName: strings.TrimSpace(string(out))}
+}
+
+func readOSRelease() *OSInfo {
+	if IsFileNotExist(_OS_RELEASE_ETC) {
+		return nil
+	}
+
+	contents, err := ioutil.ReadFile(_OS_RELEASE_ETC)
+	if err != nil {
+		logger.Infof("Read File Failed In Read Os Release: %s", err)
+		return nil
+	}
+
+	info := &OSInfo{}
+	lines := strings.Split(string(contents), "\n")
+	for _, line := range lines {
+		vars := strings.SplitN(line, "=", 2)
+		if len(vars) < 2 {
+			continue
+		}
+		value := strings.Trim(vars[1], `"`)
+
+		switch vars[0] {
+		case "PRETTY_NAME":
+			info.PrettyName = value
+		case "ID":
+			info.ID = value
+		case "VERSION_ID":
+			info.VersionID = value
+		case "VERSION_CODENAME":
+			info.VersionCodename = value
+		}
+	}
+
+	if info.PrettyName == "" {
+		return nil
+	}
+
+	return info
+}
+
+// GetKernelVersion优先从/proc/version中提取内核版本号，读取失败时回退到
+// `uname -r`。
+func GetKernelVersion() string {
+	if !IsFileNotExist(_PROC_VERSION) {
+		contents, err := ioutil.ReadFile(_PROC_VERSION)
+		if err == nil {
+			fields := strings.Fields(string(contents))
+			if len(fields) >= 3 {
+				return fields[2]
+			}
+		}
+	}
+
+	cmd := exec.Command("/bin/uname", "-r")
+	out, err := cmd.Output()
+	if err != nil {
+		logger.Infof("Exec 'uname -r' Failed In Get Kernel Version: %s", err)
+		return ""
+	}
+
+	return strings.TrimSpace(string(out))
+}
+
+// GetHostname返回主机名。
+func GetHostname() string {
+	name, err := os.Hostname()
+	if err != nil {
+		logger.Infof("Get Hostname Failed: %s", err)
+		return ""
+	}
+
+	return name
+}
+
+// IsContainerized判断当前是否运行在容器环境中，通过检查/.dockerenv是否
+// 存在以及/proc/1/cgroup中是否包含docker、lxc、kubepods等标记来判断。
+func IsContainerized() bool {
+	if !IsFileNotExist(_DOCKERENV) {
+		return true
+	}
+
+	if IsFileNotExist(_PROC_1_CGROUP) {
+		return false
+	}
+
+	contents, err := ioutil.ReadFile(_PROC_1_CGROUP)
+	if err != nil {
+		return false
+	}
+
+	for _, marker := range []string{"docker", "lxc", "kubepods"} {
+		if strings.Contains(string(contents), marker) {
+			return true
+		}
+	}
+
+	return false
+}
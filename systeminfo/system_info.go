@@ -5,10 +5,13 @@ import (
 	"io/ioutil"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"pkg.linuxdeepin.com/lib/dbus"
 	"pkg.linuxdeepin.com/lib/log"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 )
 
 type SystemInfo struct {
@@ -17,17 +20,60 @@ type SystemInfo struct {
 	MemoryCap  uint64
 	SystemType int64
 	DiskCap    uint64
+
+	CpuSockets int
+	CpuCores   int
+	CpuThreads int
+	CpuMHz     float64
+
+	MemFree      uint64
+	MemAvailable uint64
+
+	OSPrettyName      string
+	OSID              string
+	OSVersionID       string
+	OSVersionCodename string
+	KernelVersion     string
+	Hostname          string
+	IsContainer       bool
+
+	BatteryPresent    bool
+	BatteryPercentage float64
+	BatteryState      string
+	CpuTemperature    float64
+
+	// PropertiesChanged是信号，在电池或温度等属性发生变化后被触发。
+	PropertiesChanged func(name string)
+	// MemoryChanged是信号，在内存压力等级（normal、low、critical）发生变化后被触发。
+	MemoryChanged func(level string)
+	// DisksChanged是信号，在磁盘或分区被插入、拔出后被触发，不携带具体磁盘
+	// 信息，接收者应重新调用GetDiskInfo获取最新列表。
+	DisksChanged func()
+
+	// mu保护refreshProperties周期性写入的字段（Battery*、CpuTemperature、
+	// MemFree、MemAvailable、memPressureLevel），避免刷新goroutine与D-Bus
+	// 属性读取并发访问同一字段时读到撕裂的中间状态。
+	mu               sync.Mutex
+	memPressureLevel string
 }
 
 const (
 	_VERSION_ETC = "/etc/lsb-release"
 	_VERSION_KEY = "DISTRIB_RELEASE"
 
-	_PROC_CPU_INFO = "/proc/cpuinfo"
-	_PROC_CPU_KEY  = "model name"
+	_BATTERY_GLOB      = "/sys/class/power_supply/BAT*/uevent"
+	_BATTERY_KEY_PRES  = "POWER_SUPPLY_PRESENT"
+	_BATTERY_KEY_CAP   = "POWER_SUPPLY_CAPACITY"
+	_BATTERY_KEY_STATE = "POWER_SUPPLY_STATUS"
 
-	_PROC_MEM_INFO = "/proc/meminfo"
-	_PROC_MEM_KEY  = "MemTotal"
+	_THERMAL_ZONE_GLOB = "/sys/class/thermal/thermal_zone*/temp"
+
+	_propertiesPollInterval = 30 * time.Second
+
+	// 内存压力等级阈值：MemAvailable占MemTotal的百分比低于或等于这些值时，
+	// 认为系统分别进入low、critical等级。
+	_memPressureLowPercent      = 15.0
+	_memPressureCriticalPercent = 5.0
 )
 
 var (
@@ -70,64 +116,26 @@ func GetVersion() (version string) {
 	return
 }
 
+// GetCpuInfo返回形如"N cores @ X GHz"的处理器描述。底层由ReadCpuInfo提供，
+// 不再是简单地数"model name"出现的次数再拼接" x N"。
 func GetCpuInfo() string {
-	if IsFileNotExist(_PROC_CPU_INFO) {
-		return "Unknown"
-	}
-	contents, err := ioutil.ReadFile(_PROC_CPU_INFO)
+	info, err := ReadCpuInfo()
 	if err != nil {
-		logger.Infof("Read File Failed In Get CPU Info: %s",
-			err)
-		return ""
-	}
-
-	info := ""
-	cnt := 0
-	lines := strings.Split(string(contents), "\n")
-	for _, line := range lines {
-		if strings.Contains(line, _PROC_CPU_KEY) {
-			vars := strings.Split(line, ":")
-			l := len(vars)
-			if l < 2 {
-				break
-			}
-			cnt++
-			if info == "" {
-				info += vars[1]
-			}
-		}
+		return "Unknown"
 	}
-	info += " x "
-	info += strconv.FormatInt(int64(cnt), 10)
 
-	return strings.TrimSpace(info)
+	return info.String()
 }
 
+// GetMemoryCap返回内存总量（字节）。保留是为了兼容旧调用方，新代码应直接
+// 使用ReadMemInfo获取完整的内存统计。
 func GetMemoryCap() (memCap uint64) {
-	if IsFileNotExist(_PROC_MEM_INFO) {
-		return 0
-	}
-	contents, err := ioutil.ReadFile(_PROC_MEM_INFO)
+	info, err := ReadMemInfo()
 	if err != nil {
-		logger.Infof("Read File Failed In Get Memory Cap: %s",
-			err)
 		return 0
 	}
 
-	lines := strings.Split(string(contents), "\n")
-	for _, line := range lines {
-		if strings.Contains(line, _PROC_MEM_KEY) {
-			fields := strings.Fields(line)
-			l := len(fields)
-			if l < 2 {
-				break
-			}
-			memCap, _ = strconv.ParseUint(fields[1], 10, 64)
-			break
-		}
-	}
-
-	return (memCap * 1024)
+	return info.MemTotal
 }
 
 func GetSystemType() (sysType int64) {
@@ -152,6 +160,90 @@ func GetSystemType() (sysType int64) {
 	return sysType
 }
 
+// GetBatteryInfo读取/sys/class/power_supply/BAT*/uevent，返回电池是否存在、
+// 电量百分比以及充放电状态（POWER_SUPPLY_STATUS的原始值，如Charging、
+// Discharging、Not charging、Full；读不到时为Unknown）。
+// 如果存在多块电池，只读取枚举到的第一块。
+func GetBatteryInfo() (present bool, percentage float64, state string) {
+	state = "Unknown"
+
+	matches, err := filepath.Glob(_BATTERY_GLOB)
+	if err != nil || len(matches) == 0 {
+		return
+	}
+
+	contents, err := ioutil.ReadFile(matches[0])
+	if err != nil {
+		logger.Infof("Read File Failed In Get Battery Info: %s", err)
+		return
+	}
+
+	lines := strings.Split(string(contents), "\n")
+	for _, line := range lines {
+		vars := strings.SplitN(line, "=", 2)
+		if len(vars) < 2 {
+			continue
+		}
+		switch vars[0] {
+		case _BATTERY_KEY_PRES:
+			present = vars[1] == "1"
+		case _BATTERY_KEY_CAP:
+			percentage, _ = strconv.ParseFloat(vars[1], 64)
+		case _BATTERY_KEY_STATE:
+			state = vars[1]
+		}
+	}
+
+	return
+}
+
+// GetThermalInfo读取/sys/class/thermal/thermal_zone*/temp，返回各温区的平均
+// 温度（单位：摄氏度）。内核以千分之一摄氏度为单位上报该值。
+func GetThermalInfo() (temperature float64) {
+	matches, err := filepath.Glob(_THERMAL_ZONE_GLOB)
+	if err != nil || len(matches) == 0 {
+		return 0
+	}
+
+	var sum float64
+	var cnt int
+	for _, zone := range matches {
+		contents, err := ioutil.ReadFile(zone)
+		if err != nil {
+			continue
+		}
+		milliC, err := strconv.ParseFloat(strings.TrimSpace(string(contents)), 64)
+		if err != nil {
+			continue
+		}
+		sum += milliC / 1000
+		cnt++
+	}
+	if cnt == 0 {
+		return 0
+	}
+
+	return sum / float64(cnt)
+}
+
+// memPressureLevel根据MemAvailable占MemTotal的百分比，返回normal、low或
+// critical三档内存压力等级。
+func memPressureLevel(info *MemInfo) string {
+	if info.MemTotal == 0 {
+		return "normal"
+	}
+
+	percent := float64(info.MemAvailable) / float64(info.MemTotal) * 100
+	switch {
+	case percent <= _memPressureCriticalPercent:
+		return "critical"
+	case percent <= _memPressureLowPercent:
+		return "low"
+	default:
+		return "normal"
+	}
+}
+
 func GetDiskCap() (diskCap uint64) {
 	driList := []dbus.ObjectPath{}
 	obj, err := udisks2.NewObjectManager("org.freedesktop.UDisks2", "/org/freedesktop/UDisks2")
@@ -201,10 +293,82 @@ func NewSystemInfo() *SystemInfo {
 	sys.MemoryCap = GetMemoryCap()
 	sys.SystemType = GetSystemType()
 	sys.DiskCap = GetDiskCap()
+	sys.BatteryPresent, sys.BatteryPercentage, sys.BatteryState = GetBatteryInfo()
+	sys.CpuTemperature = GetThermalInfo()
+
+	if cpu, err := ReadCpuInfo(); err == nil {
+		sys.CpuSockets = cpu.Sockets
+		sys.CpuCores = cpu.Cores
+		sys.CpuThreads = cpu.Threads
+		sys.CpuMHz = cpu.MHz
+	}
+
+	if mem, err := ReadMemInfo(); err == nil {
+		sys.MemFree = mem.MemFree
+		sys.MemAvailable = mem.MemAvailable
+		sys.memPressureLevel = memPressureLevel(mem)
+	}
+
+	osInfo := GetOperatingSystem()
+	sys.OSPrettyName = osInfo.PrettyName
+	sys.OSID = osInfo.ID
+	sys.OSVersionID = osInfo.VersionID
+	sys.OSVersionCodename = osInfo.VersionCodename
+	sys.KernelVersion = GetKernelVersion()
+	sys.Hostname = GetHostname()
+	sys.IsContainer = IsContainerized()
 
 	return sys
 }
 
+// refreshProperties周期性地重新读取电池和温度信息，变化时更新属性并触发
+// PropertiesChanged信号，使控制中心无需自行轮询/proc、/sys。
+func (sys *SystemInfo) refreshProperties() {
+	for {
+		time.Sleep(_propertiesPollInterval)
+
+		present, percentage, state := GetBatteryInfo()
+		temperature := GetThermalInfo()
+		mem, memErr := ReadMemInfo()
+
+		sys.mu.Lock()
+		batteryChanged := present != sys.BatteryPresent || percentage != sys.BatteryPercentage || state != sys.BatteryState
+		if batteryChanged {
+			sys.BatteryPresent = present
+			sys.BatteryPercentage = percentage
+			sys.BatteryState = state
+		}
+
+		thermalChanged := temperature != sys.CpuTemperature
+		if thermalChanged {
+			sys.CpuTemperature = temperature
+		}
+
+		var memoryLevel string
+		memoryChanged := false
+		if memErr == nil {
+			sys.MemFree = mem.MemFree
+			sys.MemAvailable = mem.MemAvailable
+			memoryLevel = memPressureLevel(mem)
+			memoryChanged = memoryLevel != sys.memPressureLevel
+			if memoryChanged {
+				sys.memPressureLevel = memoryLevel
+			}
+		}
+		sys.mu.Unlock()
+
+		if batteryChanged {
+			dbus.Emit(sys, "PropertiesChanged", "Battery")
+		}
+		if thermalChanged {
+			dbus.Emit(sys, "PropertiesChanged", "Thermal")
+		}
+		if memoryChanged {
+			dbus.Emit(sys, "MemoryChanged", memoryLevel)
+		}
+	}
+}
+
 func Start() {
 	logger.BeginTracing()
 
@@ -213,6 +377,9 @@ func Start() {
 	if err != nil {
 		panic(err)
 	}
+
+	go sys.refreshProperties()
+	go sys.watchDisks()
 }
 func Stop() {
 	logger.EndTracing()